@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultRefreshInterval is used when Config.Frequency is unset, so the
+// exporter still decouples scrapes from upstream calls out of the box.
+const defaultRefreshInterval = 60 * time.Second
+
+// defaultFundamentalsInterval is used when Config.FundamentalsFrequency is
+// unset. Fundamentals change far less often than quotes, so they default to
+// a much slower cadence to conserve API quota.
+const defaultFundamentalsInterval = 1 * time.Hour
+
+var (
+	scrapeDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "stock_scrape_duration_seconds",
+		Help:    "Time taken to refresh the quote and news cache for all configured symbols",
+		Buckets: prometheus.DefBuckets,
+	})
+	scrapeErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "stock_scrape_errors_total",
+		Help: "Number of failed upstream fetches per symbol since start",
+	}, []string{"symbol"})
+	lastScrapeSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "stock_last_scrape_success_timestamp",
+		Help: "Unix timestamp of the last successful fetch for a symbol",
+	}, []string{"symbol"})
+	newsSeenTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "stock_news_seen_total",
+		Help: "Number of company news articles fetched from the provider, including already-seen ones",
+	}, []string{"symbol"})
+	newsNewTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "stock_news_new_total",
+		Help: "Number of previously unseen company news articles emitted",
+	}, []string{"symbol"})
+)
+
+func init() {
+	prometheus.MustRegister(scrapeDuration, scrapeErrors, lastScrapeSuccess, newsSeenTotal, newsNewTotal)
+}
+
+// quoteCacheEntry holds the most recently fetched data for a single symbol.
+type quoteCacheEntry struct {
+	quote        *Quote
+	news         []NewsItem
+	fundamentals *Fundamentals
+}
+
+// QuoteCache periodically refreshes quotes and news for a set of symbols in
+// the background, so that Collect() can serve scrapes from memory instead
+// of blocking on upstream APIs and their rate limits.
+type QuoteCache struct {
+	mu         sync.RWMutex
+	entries    map[string]quoteCacheEntry
+	symbols    []string
+	providers  map[string]QuoteProvider
+	newsStore  *NewsStore
+	newsMaxAge time.Duration
+}
+
+// NewQuoteCache returns a new QuoteCache for symbols, resolving each one
+// through providers. newsStore deduplicates company news across refreshes;
+// it may be nil, in which case news is re-emitted on every refresh.
+// newsMaxAge bounds how long seen articles are remembered before they are
+// pruned from newsStore; zero disables pruning.
+func NewQuoteCache(symbols []string, providers map[string]QuoteProvider, newsStore *NewsStore, newsMaxAge time.Duration) *QuoteCache {
+	return &QuoteCache{
+		entries:    make(map[string]quoteCacheEntry),
+		symbols:    symbols,
+		providers:  providers,
+		newsStore:  newsStore,
+		newsMaxAge: newsMaxAge,
+	}
+}
+
+// Run refreshes quotes and news every interval, and fundamentals every
+// fundamentalsInterval, until ctx is done. It performs one of each refresh
+// immediately so the cache is warm before the first tick.
+func (c *QuoteCache) Run(ctx context.Context, interval, fundamentalsInterval time.Duration) {
+	c.refresh(ctx)
+	c.refreshFundamentals(ctx)
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var fundamentalsTick <-chan time.Time
+	if fundamentalsInterval > 0 {
+		fundamentalsTicker := time.NewTicker(fundamentalsInterval)
+		defer fundamentalsTicker.Stop()
+		fundamentalsTick = fundamentalsTicker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refresh(ctx)
+		case <-fundamentalsTick:
+			c.refreshFundamentals(ctx)
+		}
+	}
+}
+
+func (c *QuoteCache) refresh(ctx context.Context) {
+	start := time.Now()
+	today := time.Now()
+	hadError := false
+	for _, sym := range c.symbols {
+		provider, ok := c.providers[sym]
+		if !ok {
+			slog.Warn("No provider configured for symbol, skipping refresh", "symbol", sym)
+			continue
+		}
+
+		succeeded := false
+		var quote *Quote
+		var news []NewsItem
+		var gotQuote, gotNews bool
+
+		quoteStart := time.Now()
+		q, err := provider.Quote(ctx, sym)
+		exporterScrapeDuration.WithLabelValues(provider.Name(), sym).Observe(time.Since(quoteStart).Seconds())
+		if err != nil {
+			slog.Error("Failed to refresh quote", "symbol", sym, "provider", provider.Name(), "err", err)
+			scrapeErrors.WithLabelValues(sym).Inc()
+			exporterScrapeErrors.WithLabelValues(provider.Name(), sym, "quote").Inc()
+			hadError = true
+		} else {
+			quote, gotQuote = q, true
+			succeeded = true
+		}
+
+		newsStart := time.Now()
+		n, err := provider.News(ctx, sym, today, today)
+		exporterScrapeDuration.WithLabelValues(provider.Name(), sym).Observe(time.Since(newsStart).Seconds())
+		if err != nil {
+			if !errors.Is(err, ErrUnsupported) {
+				slog.Error("Failed to refresh company news", "symbol", sym, "provider", provider.Name(), "err", err)
+				scrapeErrors.WithLabelValues(sym).Inc()
+				exporterScrapeErrors.WithLabelValues(provider.Name(), sym, "news").Inc()
+				hadError = true
+			}
+		} else {
+			news, gotNews = c.dedupNews(sym, n), true
+			succeeded = true
+		}
+
+		if succeeded {
+			lastScrapeSuccess.WithLabelValues(sym).Set(float64(time.Now().Unix()))
+		}
+
+		// Update only the fields this pass actually refreshed: entries
+		// also carry fundamentals, set independently by
+		// refreshFundamentals on its own slower cadence, and must not be
+		// clobbered back to nil here. News is appended rather than
+		// replaced: dedupNews has already marked these articles as seen,
+		// so if a scrape hasn't drained the pending news since the last
+		// refresh, overwriting it here would discard them for good.
+		c.mu.Lock()
+		entry := c.entries[sym]
+		if gotQuote {
+			entry.quote = quote
+		}
+		if gotNews {
+			entry.news = append(entry.news, news...)
+		}
+		c.entries[sym] = entry
+		c.mu.Unlock()
+	}
+	scrapeDuration.Observe(time.Since(start).Seconds())
+	if !hadError {
+		exporterLastScrapeSuccess.Set(float64(time.Now().Unix()))
+	}
+
+	if c.newsStore != nil {
+		if err := c.newsStore.Prune(c.newsMaxAge); err != nil {
+			slog.Error("Failed to prune news store", "err", err)
+		}
+	}
+}
+
+// refreshFundamentals refreshes slow-moving per-company data for all
+// symbols. It runs independently from refresh() so a misbehaving
+// Fundamentals call cannot hold up the quote/news cadence, or vice versa.
+func (c *QuoteCache) refreshFundamentals(ctx context.Context) {
+	for _, sym := range c.symbols {
+		provider, ok := c.providers[sym]
+		if !ok {
+			continue
+		}
+		start := time.Now()
+		fundamentals, err := provider.Fundamentals(ctx, sym)
+		exporterScrapeDuration.WithLabelValues(provider.Name(), sym).Observe(time.Since(start).Seconds())
+		if err != nil {
+			if !errors.Is(err, ErrUnsupported) {
+				slog.Error("Failed to refresh fundamentals", "symbol", sym, "provider", provider.Name(), "err", err)
+				scrapeErrors.WithLabelValues(sym).Inc()
+				exporterScrapeErrors.WithLabelValues(provider.Name(), sym, "fundamentals").Inc()
+			}
+			continue
+		}
+		c.mu.Lock()
+		entry := c.entries[sym]
+		entry.fundamentals = fundamentals
+		c.entries[sym] = entry
+		c.mu.Unlock()
+	}
+}
+
+// dedupNews filters news down to articles not already recorded in
+// c.newsStore, marking them as seen as a side effect. If c.newsStore is
+// nil, news is returned unfiltered.
+func (c *QuoteCache) dedupNews(symbol string, news []NewsItem) []NewsItem {
+	if c.newsStore == nil {
+		return news
+	}
+	fresh := make([]NewsItem, 0, len(news))
+	for _, n := range news {
+		newsSeenTotal.WithLabelValues(symbol).Inc()
+		isNew, err := c.newsStore.MarkSeen(symbol, n.ID, n.Timestamp)
+		if err != nil {
+			slog.Error("Failed to record news as seen", "news_id", n.ID, "symbol", symbol, "err", err)
+			continue
+		}
+		if isNew {
+			newsNewTotal.WithLabelValues(symbol).Inc()
+			fresh = append(fresh, n)
+		}
+	}
+	return fresh
+}
+
+// Get returns the most recently cached quote, pending news and fundamentals
+// for symbol, if any. It does not consume the pending news; use TakeNews to
+// deliver each article exactly once.
+func (c *QuoteCache) Get(symbol string) (*Quote, []NewsItem, *Fundamentals) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry := c.entries[symbol]
+	return entry.quote, entry.news, entry.fundamentals
+}
+
+// TakeNews returns the news accumulated for symbol since the last call to
+// TakeNews and clears it from the cache. Successive refresh cycles append
+// newly discovered articles rather than replacing the pending batch, so
+// articles survive even if multiple refreshes elapse between scrapes; this
+// method is how a scrape marks them delivered.
+func (c *QuoteCache) TakeNews(symbol string) []NewsItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.entries[symbol]
+	news := entry.news
+	entry.news = nil
+	c.entries[symbol] = entry
+	return news
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	companyNewsDesc = prometheus.NewDesc(
+		"stock_company_news",
+		"Stocks - Company News",
+		[]string{"symbol", "headline", "url", "id"},
+		nil,
+	)
+	stockPriceDesc = prometheus.NewDesc(
+		"stock_price",
+		"Stocks - Symbol price",
+		[]string{"symbol"},
+		nil,
+	)
+	stockOpenDesc = prometheus.NewDesc(
+		"stock_open",
+		"Stocks - Opening price of the day",
+		[]string{"symbol"},
+		nil,
+	)
+	stockHighDesc = prometheus.NewDesc(
+		"stock_high",
+		"Stocks - High price of the day",
+		[]string{"symbol"},
+		nil,
+	)
+	stockLowDesc = prometheus.NewDesc(
+		"stock_low",
+		"Stocks - Low price of the day",
+		[]string{"symbol"},
+		nil,
+	)
+	stockPreviousCloseDesc = prometheus.NewDesc(
+		"stock_previous_close",
+		"Stocks - Previous close price",
+		[]string{"symbol"},
+		nil,
+	)
+	stockChangeDesc = prometheus.NewDesc(
+		"stock_change",
+		"Stocks - Change from previous close",
+		[]string{"symbol"},
+		nil,
+	)
+	stockChangePercentDesc = prometheus.NewDesc(
+		"stock_change_percent",
+		"Stocks - Percent change from previous close",
+		[]string{"symbol"},
+		nil,
+	)
+	stockMarketCapDesc = prometheus.NewDesc(
+		"stock_market_cap",
+		"Stocks - Market capitalization",
+		[]string{"symbol"},
+		nil,
+	)
+	stockPEDesc = prometheus.NewDesc(
+		"stock_pe_ratio",
+		"Stocks - Price/earnings ratio",
+		[]string{"symbol"},
+		nil,
+	)
+	stockWeek52HighDesc = prometheus.NewDesc(
+		"stock_52_week_high",
+		"Stocks - 52-week high price",
+		[]string{"symbol"},
+		nil,
+	)
+	stockWeek52LowDesc = prometheus.NewDesc(
+		"stock_52_week_low",
+		"Stocks - 52-week low price",
+		[]string{"symbol"},
+		nil,
+	)
+	stockDividendYieldDesc = prometheus.NewDesc(
+		"stock_dividend_yield",
+		"Stocks - Indicated annual dividend yield",
+		[]string{"symbol"},
+		nil,
+	)
+	stockShareFloatDesc = prometheus.NewDesc(
+		"stock_share_float",
+		"Stocks - Outstanding share float",
+		[]string{"symbol"},
+		nil,
+	)
+)
+
+// NewStocksCollector returns a new StocksCollector that serves symbols from
+// cache.
+func NewStocksCollector(symbols []string, cache *QuoteCache) *StocksCollector {
+	return &StocksCollector{
+		symbols: symbols,
+		cache:   cache,
+	}
+}
+
+// StocksCollector is a custom collector for point-in-time metrics that can
+// be used as Grafana annotations. It never calls upstream providers itself:
+// it only reads whatever QuoteCache has most recently fetched in the
+// background.
+type StocksCollector struct {
+	symbols []string
+	cache   *QuoteCache
+}
+
+// Describe implements prometheus.Collector.Describe for StocksCollector.
+func (sc *StocksCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(sc, ch)
+}
+
+// Collect implements prometheus.Collector.Collect for StocksCollector.
+func (sc *StocksCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, sym := range sc.symbols {
+		quote, _, fundamentals := sc.cache.Get(sym)
+		news := sc.cache.TakeNews(sym)
+		if quote != nil {
+			ch <- prometheus.MustNewConstMetric(stockPriceDesc, prometheus.GaugeValue, quote.Current, sym)
+			ch <- prometheus.MustNewConstMetric(stockOpenDesc, prometheus.GaugeValue, quote.Open, sym)
+			ch <- prometheus.MustNewConstMetric(stockHighDesc, prometheus.GaugeValue, quote.High, sym)
+			ch <- prometheus.MustNewConstMetric(stockLowDesc, prometheus.GaugeValue, quote.Low, sym)
+			ch <- prometheus.MustNewConstMetric(stockPreviousCloseDesc, prometheus.GaugeValue, quote.PreviousClose, sym)
+			ch <- prometheus.MustNewConstMetric(stockChangeDesc, prometheus.GaugeValue, quote.Change, sym)
+			ch <- prometheus.MustNewConstMetric(stockChangePercentDesc, prometheus.GaugeValue, quote.PercentChange, sym)
+		}
+		if fundamentals != nil {
+			ch <- prometheus.MustNewConstMetric(stockMarketCapDesc, prometheus.GaugeValue, fundamentals.MarketCap, sym)
+			ch <- prometheus.MustNewConstMetric(stockPEDesc, prometheus.GaugeValue, fundamentals.PE, sym)
+			ch <- prometheus.MustNewConstMetric(stockWeek52HighDesc, prometheus.GaugeValue, fundamentals.WeekHigh52, sym)
+			ch <- prometheus.MustNewConstMetric(stockWeek52LowDesc, prometheus.GaugeValue, fundamentals.WeekLow52, sym)
+			ch <- prometheus.MustNewConstMetric(stockDividendYieldDesc, prometheus.GaugeValue, fundamentals.DividendYield, sym)
+			ch <- prometheus.MustNewConstMetric(stockShareFloatDesc, prometheus.GaugeValue, fundamentals.ShareFloat, sym)
+		}
+		for _, n := range news {
+			ch <- prometheus.NewMetricWithTimestamp(
+				n.Timestamp,
+				prometheus.MustNewConstMetric(
+					companyNewsDesc,
+					prometheus.GaugeValue,
+					1,
+					sym,
+					n.Headline,
+					n.URL,
+					n.ID,
+				),
+			)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/insomniacslk/xjson"
+)
+
+// ProviderConfig describes a single configured QuoteProvider backend.
+type ProviderConfig struct {
+	// Name is the user-chosen identifier for this provider instance, used
+	// to refer to it from SymbolProviders and DefaultProvider.
+	Name string `json:"name"`
+	// Type selects the backend implementation: "finnhub", "yahoo",
+	// "alphavantage", "iex" or "coingecko".
+	Type string `json:"type"`
+	// APIKey is the API key or token required by the backend, if any.
+	APIKey string `json:"api_key,omitempty"`
+	// BaseURL overrides the backend's default API endpoint, if set.
+	BaseURL string `json:"base_url,omitempty"`
+}
+
+// Holding is a single portfolio position: a number of shares acquired at an
+// average cost, denominated in Currency.
+type Holding struct {
+	Shares   float64 `json:"shares"`
+	AvgCost  float64 `json:"avg_cost"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+// FXConfig configures the FX provider used to convert holdings into
+// ReportingCurrency.
+type FXConfig struct {
+	// Type selects the backend implementation: "exchangerate.host" or
+	// "static".
+	Type string `json:"type"`
+	// BaseURL overrides the backend's default API endpoint, if set.
+	BaseURL string `json:"base_url,omitempty"`
+	// Rates provides fixed "FROM/TO" rates, e.g. {"EUR/USD": 1.08}. Only
+	// used when Type is "static".
+	Rates map[string]float64 `json:"rates,omitempty"`
+}
+
+// Config is the configuration file type.
+type Config struct {
+	Symbols   []string       `json:"symbols"`
+	Frequency xjson.Duration `json:"frequency"`
+	// FundamentalsFrequency sets how often CompanyProfile2/BasicFinancials
+	// style data is refreshed. Fundamentals change far slower than quotes,
+	// so this is typically much larger than Frequency to conserve API
+	// quota. If zero, defaultFundamentalsInterval is used.
+	FundamentalsFrequency xjson.Duration `json:"fundamentals_frequency,omitempty"`
+	// FinnhubAPIKey is kept for backward compatibility with configuration
+	// files that predate Providers: it is equivalent to declaring a
+	// Finnhub entry named "finnhub" at the end of Providers, which also
+	// becomes the DefaultProvider if none is set.
+	FinnhubAPIKey string `json:"finnhub_api_key,omitempty"`
+	// Providers lists the quote backends available to this exporter.
+	Providers []ProviderConfig `json:"providers,omitempty"`
+	// SymbolProviders maps a symbol to the name of the Providers entry
+	// that should serve it, e.g. {"bitcoin-usd": "coingecko", "AAPL": "finnhub"}.
+	// Symbols with no entry fall back to DefaultProvider.
+	SymbolProviders map[string]string `json:"symbol_providers,omitempty"`
+	// DefaultProvider is the provider name used for symbols not present
+	// in SymbolProviders. If empty, the first entry in Providers is used.
+	DefaultProvider string `json:"default_provider,omitempty"`
+	// NewsStorePath is the path to the persistent BoltDB file used to
+	// deduplicate company news across scrapes. If empty, defaults to
+	// defaultNewsStorePath.
+	NewsStorePath string `json:"news_store_path,omitempty"`
+	// NewsRetention bounds how long seen news articles are remembered
+	// before being pruned from the news store. If zero, entries are kept
+	// forever.
+	NewsRetention xjson.Duration `json:"news_retention,omitempty"`
+	// Holdings declares a portfolio as symbol -> position. When set,
+	// holdings-weighted aggregate metrics are exposed alongside the
+	// regular per-symbol ones.
+	Holdings map[string]Holding `json:"holdings,omitempty"`
+	// ReportingCurrency is the currency portfolio metrics are expressed
+	// in. Defaults to defaultReportingCurrency if empty.
+	ReportingCurrency string `json:"reporting_currency,omitempty"`
+	// FX configures the provider used to convert holdings denominated in
+	// a currency other than ReportingCurrency. Required if any Holding
+	// sets a Currency different from ReportingCurrency.
+	FX *FXConfig `json:"fx,omitempty"`
+}
+
+// LoadConfig loads the configuration file into a Config type.
+func LoadConfig(filepath string) (*Config, error) {
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON config: %w", err)
+	}
+	if config.FinnhubAPIKey != "" {
+		config.Providers = append(config.Providers, ProviderConfig{
+			Name:   "finnhub",
+			Type:   "finnhub",
+			APIKey: config.FinnhubAPIKey,
+		})
+		if config.DefaultProvider == "" {
+			config.DefaultProvider = "finnhub"
+		}
+	}
+	return &config, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// FXProvider converts between currencies, used to collapse a mixed-currency
+// portfolio into a single reporting currency.
+type FXProvider interface {
+	// Name returns the provider's short identifier, e.g. "exchangerate.host".
+	Name() string
+	// Rate returns the multiplier to convert an amount in from into to.
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+const exchangeRateHostDefaultBaseURL = "https://api.exchangerate.host"
+
+// ExchangeRateHostProvider implements FXProvider on top of the free, keyless
+// exchangerate.host API.
+type ExchangeRateHostProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewExchangeRateHostProvider returns a new ExchangeRateHostProvider. If
+// baseURL is empty, the default public exchangerate.host endpoint is used.
+func NewExchangeRateHostProvider(baseURL string) *ExchangeRateHostProvider {
+	if baseURL == "" {
+		baseURL = exchangeRateHostDefaultBaseURL
+	}
+	return &ExchangeRateHostProvider{baseURL: baseURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Name implements FXProvider.
+func (p *ExchangeRateHostProvider) Name() string { return "exchangerate.host" }
+
+type exchangeRateHostResponse struct {
+	Result float64 `json:"result"`
+}
+
+// Rate implements FXProvider.
+func (p *ExchangeRateHostProvider) Rate(ctx context.Context, from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	query := url.Values{"from": {from}, "to": {to}, "amount": {"1"}}
+	u := fmt.Sprintf("%s/convert?%s", p.baseURL, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, fmt.Errorf("exchangerate.host: failed to build request for '%s->%s': %w", from, to, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("exchangerate.host: request failed for '%s->%s': %w", from, to, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("exchangerate.host: unexpected status %d for '%s->%s'", resp.StatusCode, from, to)
+	}
+	var res exchangeRateHostResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return 0, fmt.Errorf("exchangerate.host: failed to decode response for '%s->%s': %w", from, to, err)
+	}
+	if res.Result == 0 {
+		return 0, fmt.Errorf("exchangerate.host: no rate returned for '%s->%s'", from, to)
+	}
+	return res.Result, nil
+}
+
+// StaticFXProvider serves fixed, user-supplied exchange rates. Useful when
+// exchangerate.host is unreachable or does not cover a reporting currency.
+type StaticFXProvider struct {
+	rates map[string]float64 // keyed by "FROM/TO"
+}
+
+// NewStaticFXProvider returns a StaticFXProvider serving rates, keyed by
+// "FROM/TO" pairs, e.g. {"EUR/USD": 1.08}.
+func NewStaticFXProvider(rates map[string]float64) *StaticFXProvider {
+	return &StaticFXProvider{rates: rates}
+}
+
+// Name implements FXProvider.
+func (p *StaticFXProvider) Name() string { return "static" }
+
+// Rate implements FXProvider.
+func (p *StaticFXProvider) Rate(ctx context.Context, from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	rate, ok := p.rates[from+"/"+to]
+	if !ok {
+		return 0, fmt.Errorf("static: no configured rate for '%s->%s'", from, to)
+	}
+	return rate, nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// These complement the per-symbol stock_scrape_* metrics with a view
+// broken down by provider, and a single timestamp operators can alert on
+// directly, matching the instrumentation node_exporter exposes for its own
+// collectors.
+var (
+	exporterScrapeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "stock_exporter_scrape_duration_seconds",
+		Help:    "Time taken to fetch data for a single symbol from a single provider",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"provider", "symbol"})
+	exporterScrapeErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "stock_exporter_scrape_errors_total",
+		Help: "Number of failed upstream fetches by provider, symbol and reason",
+	}, []string{"provider", "symbol", "reason"})
+	exporterLastScrapeSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "stock_exporter_last_scrape_success_timestamp",
+		Help: "Unix timestamp of the last refresh cycle that completed without any provider errors",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(exporterScrapeDuration, exporterScrapeErrors, exporterLastScrapeSuccess)
+}
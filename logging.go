@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+var (
+	flagLogLevel  = flag.String("log.level", "info", "Minimum log level to emit (debug, info, warn, error)")
+	flagLogFormat = flag.String("log.format", "logfmt", "Log output format (logfmt, json)")
+)
+
+// newLogger builds the process-wide structured logger from -log.level and
+// -log.format, following the same flag convention as node_exporter and
+// other Prometheus exporters.
+func newLogger(levelStr, format string) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(levelStr)); err != nil {
+		return nil, fmt.Errorf("invalid -log.level '%s': %w", levelStr, err)
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch format {
+	case "logfmt":
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	default:
+		return nil, fmt.Errorf("invalid -log.format '%s': must be 'logfmt' or 'json'", format)
+	}
+	return slog.New(handler), nil
+}
@@ -2,16 +2,13 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
-	"io/ioutil"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
 	"time"
 
-	finnhub "github.com/Finnhub-Stock-API/finnhub-go/v2"
-	"github.com/insomniacslk/xjson"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -22,139 +19,190 @@ var (
 	flagConfigFile = flag.String("c", "config.json", "Configuration file")
 )
 
-// Config is the configuration file type.
-type Config struct {
-	Symbols       []string       `json:"symbols"`
-	Frequency     xjson.Duration `json:"frequency"`
-	FinnhubAPIKey string         `json:"finnhub_api_key"`
-}
-
-// LoadConfig loads the configuration file into a Config type.
-func LoadConfig(filepath string) (*Config, error) {
-	data, err := ioutil.ReadFile(filepath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON config: %w", err)
+// buildProviders instantiates a QuoteProvider for each entry in
+// config.Providers, keyed by its configured name.
+func buildProviders(config *Config) (map[string]QuoteProvider, error) {
+	providers := make(map[string]QuoteProvider, len(config.Providers))
+	for _, pc := range config.Providers {
+		var provider QuoteProvider
+		switch pc.Type {
+		case "finnhub":
+			provider = NewFinnhubProvider(pc.APIKey)
+		case "yahoo":
+			provider = NewYahooProvider(pc.BaseURL)
+		case "alphavantage":
+			provider = NewAlphaVantageProvider(pc.APIKey, pc.BaseURL)
+		case "iex":
+			provider = NewIEXProvider(pc.APIKey, pc.BaseURL)
+		case "coingecko":
+			provider = NewCoinGeckoProvider(pc.BaseURL)
+		default:
+			return nil, fmt.Errorf("unknown provider type '%s' for provider '%s'", pc.Type, pc.Name)
+		}
+		providers[pc.Name] = provider
 	}
-	return &config, nil
+	return providers, nil
 }
 
-// NewStocksCollector returns a new StocksCollector.
-func NewStocksCollector(ctx context.Context, client *finnhub.DefaultApiService, symbols []string) *StocksCollector {
-	return &StocksCollector{
-		ctx:     ctx,
-		client:  client,
-		symbols: symbols,
+// buildFXProvider instantiates the FXProvider described by config.FX. It
+// returns nil if config.FX is unset.
+func buildFXProvider(config *Config) (FXProvider, error) {
+	if config.FX == nil {
+		return nil, nil
+	}
+	switch config.FX.Type {
+	case "exchangerate.host":
+		return NewExchangeRateHostProvider(config.FX.BaseURL), nil
+	case "static":
+		return NewStaticFXProvider(config.FX.Rates), nil
+	default:
+		return nil, fmt.Errorf("unknown FX provider type '%s'", config.FX.Type)
 	}
 }
 
-// StocksCollector is a custom collector for point-in-time metrics that can
-// be used as Grafana annotations.
-type StocksCollector struct {
-	ctx     context.Context
-	client  *finnhub.DefaultApiService
-	symbols []string
-}
-
-// Describe implements prometheus.Collector.Describe for StocksCollector.
-func (sc *StocksCollector) Describe(ch chan<- *prometheus.Desc) {
-	prometheus.DescribeByCollect(sc, ch)
-}
-
-var (
-	companyNewsDesc = prometheus.NewDesc(
-		"stock_company_news",
-		"Stocks - Company News",
-		[]string{"symbol", "headline", "url", "id"},
-		nil,
-	)
-	stockPriceDesc = prometheus.NewDesc(
-		"stock_price",
-		"Stocks - Symbol price",
-		[]string{"symbol"},
-		nil,
-	)
-)
-
-// Collect implements prometheus.Collector.Collect for StocksCollector.
-func (sc *StocksCollector) Collect(ch chan<- prometheus.Metric) {
-	// update company news as timestamped metric, useful for Grafana annotations
-	today := time.Now().Format("2006-01-02")
-	from, to := today, today
-	fmt.Printf("Fetching company news for %v from %s to %s\n", sc.symbols, from, to)
-	for _, sym := range sc.symbols {
-		// collect stock price
-		fmt.Printf("Getting stock price for %s\n", sym)
-		resPrice, _, err := sc.client.Quote(sc.ctx).Symbol(sym).Execute()
-		if err != nil {
-			log.Printf("Failed to get stock price for '%s': %v", sym, err)
+// fxPairs returns the distinct (from, to) currency pairs that need
+// converting to serve holdings in reportingCurrency.
+func fxPairs(holdings map[string]Holding, reportingCurrency string) [][2]string {
+	seen := make(map[string]bool)
+	var pairs [][2]string
+	for _, h := range holdings {
+		currency := h.Currency
+		if currency == "" || currency == reportingCurrency {
 			continue
 		}
-		if resPrice.C == nil {
-			log.Printf("Warning: skipping %s that has current price set to `nil`", sym)
-		} else {
-			// update values
-			ch <- prometheus.MustNewConstMetric(stockPriceDesc, prometheus.GaugeValue, float64(*resPrice.C), sym)
+		if seen[currency] {
+			continue
 		}
+		seen[currency] = true
+		pairs = append(pairs, [2]string{currency, reportingCurrency})
+	}
+	return pairs
+}
 
-		// collect company news
-		resNews, _, err := sc.client.CompanyNews(sc.ctx).Symbol(sym).From(from).To(to).Execute()
-		if err != nil {
-			fmt.Printf("Failed to get company news for '%s': %v\n", sym, err)
-			continue
+// resolveSymbolProviders maps each symbol to the QuoteProvider that should
+// serve it, based on config.SymbolProviders and config.DefaultProvider.
+func resolveSymbolProviders(config *Config, providers map[string]QuoteProvider) (map[string]QuoteProvider, error) {
+	defaultName := config.DefaultProvider
+	if defaultName == "" {
+		for _, pc := range config.Providers {
+			defaultName = pc.Name
+			break
+		}
+	}
+	resolved := make(map[string]QuoteProvider, len(config.Symbols))
+	for _, sym := range config.Symbols {
+		name, ok := config.SymbolProviders[sym]
+		if !ok {
+			name = defaultName
 		}
-		fmt.Printf("Found %d company news for %s\n", len(resNews), sym)
-		for _, news := range resNews {
-			if news.Datetime == nil || news.Headline == nil || news.Id == nil || news.Url == nil {
-				fmt.Printf("Skipping company news for %s: found nil fields where non-nil wanted: %+v\n", sym, news)
-				continue
-			}
-			// FIXME collect this metric exactly once
-			ch <- prometheus.NewMetricWithTimestamp(
-				time.Unix(*news.Datetime, 0),
-				prometheus.MustNewConstMetric(
-					companyNewsDesc,
-					prometheus.GaugeValue,
-					1,
-					sym,
-					*news.Headline,
-					*news.Url,
-					fmt.Sprintf("%d", *news.Id),
-				),
-			)
+		provider, ok := providers[name]
+		if !ok {
+			return nil, fmt.Errorf("symbol '%s' refers to unknown provider '%s'", sym, name)
 		}
+		resolved[sym] = provider
 	}
-
+	return resolved, nil
 }
 
 func main() {
 	flag.Parse()
+
+	logger, err := newLogger(*flagLogLevel, *flagLogFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(logger)
+
 	config, err := LoadConfig(*flagConfigFile)
 	if err != nil {
-		log.Fatalf("Failed to load configuration file '%s': %v", *flagConfigFile, err)
+		slog.Error("Failed to load configuration file", "path", *flagConfigFile, "err", err)
+		os.Exit(1)
 	}
-	fmt.Printf("Symbols (%d): %s\n", len(config.Symbols), config.Symbols)
+	slog.Info("Loaded configuration", "num_symbols", len(config.Symbols), "symbols", config.Symbols)
 
 	if len(config.Symbols) == 0 {
-		log.Fatalf("Must specify at least one symbol")
+		slog.Error("Must specify at least one symbol")
+		os.Exit(1)
+	}
+	if len(config.Providers) == 0 {
+		slog.Error("Must specify at least one provider")
+		os.Exit(1)
+	}
+
+	providers, err := buildProviders(config)
+	if err != nil {
+		slog.Error("Failed to build providers", "err", err)
+		os.Exit(1)
+	}
+	symbolProviders, err := resolveSymbolProviders(config, providers)
+	if err != nil {
+		slog.Error("Failed to resolve symbol providers", "err", err)
+		os.Exit(1)
 	}
 
-	// open finnhub client
-	cfg := finnhub.NewConfiguration()
-	cfg.AddDefaultHeader("X-Finnhub-Token", config.FinnhubAPIKey)
-	cl := finnhub.NewAPIClient(cfg).DefaultApi
 	ctx := context.Background()
 
+	newsStorePath := config.NewsStorePath
+	if newsStorePath == "" {
+		newsStorePath = defaultNewsStorePath
+	}
+	newsStore, err := OpenNewsStore(newsStorePath)
+	if err != nil {
+		slog.Error("Failed to open news store", "err", err)
+		os.Exit(1)
+	}
+	defer newsStore.Close()
+
+	// run the cache-and-refresh loop in the background, decoupled from
+	// Prometheus scrapes, so Collect() never blocks on upstream APIs
+	interval := time.Duration(config.Frequency)
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	fundamentalsInterval := time.Duration(config.FundamentalsFrequency)
+	if fundamentalsInterval <= 0 {
+		fundamentalsInterval = defaultFundamentalsInterval
+	}
+	cache := NewQuoteCache(config.Symbols, symbolProviders, newsStore, time.Duration(config.NewsRetention))
+	go cache.Run(ctx, interval, fundamentalsInterval)
+
 	// register collectors
-	stocksCollector := NewStocksCollector(ctx, cl, config.Symbols)
+	stocksCollector := NewStocksCollector(config.Symbols, cache)
 	if err := prometheus.Register(stocksCollector); err != nil {
-		log.Fatalf("Failed to register stocks collector: %v", err)
+		slog.Error("Failed to register stocks collector", "err", err)
+		os.Exit(1)
+	}
+
+	if len(config.Holdings) > 0 {
+		reportingCurrency := config.ReportingCurrency
+		if reportingCurrency == "" {
+			reportingCurrency = defaultReportingCurrency
+		}
+		pairs := fxPairs(config.Holdings, reportingCurrency)
+		fxProvider, err := buildFXProvider(config)
+		if err != nil {
+			slog.Error("Failed to build FX provider", "err", err)
+			os.Exit(1)
+		}
+		if fxProvider == nil && len(pairs) > 0 {
+			slog.Error("Holdings require currency conversion but no FX provider is configured")
+			os.Exit(1)
+		}
+		fxCache := NewFXCache(fxProvider, pairs)
+		go fxCache.Run(ctx, defaultFXRefreshInterval)
+
+		portfolioCollector := NewPortfolioCollector(config.Holdings, reportingCurrency, cache, fxCache)
+		if err := prometheus.Register(portfolioCollector); err != nil {
+			slog.Error("Failed to register portfolio collector", "err", err)
+			os.Exit(1)
+		}
 	}
 
 	http.Handle(*flagPath, promhttp.Handler())
-	log.Printf("Starting server on %s", *flagListen)
-	log.Fatal(http.ListenAndServe(*flagListen, nil))
+	slog.Info("Starting server", "address", *flagListen)
+	if err := http.ListenAndServe(*flagListen, nil); err != nil {
+		slog.Error("Server exited", "err", err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	newsStoreBucket = "seen_news"
+	// defaultNewsStorePath is used when Config.NewsStorePath is unset.
+	defaultNewsStorePath = "stock_news_seen.db"
+)
+
+// NewsStore is a small persistent key-value store that remembers which
+// company news articles have already been emitted, keyed by
+// "<symbol>/<news id>". This lets the collector emit each article exactly
+// once instead of re-emitting the same historical timestamps on every
+// scrape, which Prometheus rejects as out-of-order samples.
+type NewsStore struct {
+	db *bolt.DB
+}
+
+// OpenNewsStore opens (creating if necessary) a NewsStore at path.
+func OpenNewsStore(path string) (*NewsStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open news store '%s': %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(newsStoreBucket))
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize news store '%s': %w", path, err)
+	}
+	return &NewsStore{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (s *NewsStore) Close() error {
+	return s.db.Close()
+}
+
+func newsStoreKey(symbol, id string) []byte {
+	return []byte(symbol + "/" + id)
+}
+
+// MarkSeen records that the article identified by (symbol, id) was seen at
+// seenAt, if it was not already known. isNew is true if this call is the
+// one that newly recorded it.
+func (s *NewsStore) MarkSeen(symbol, id string, seenAt time.Time) (isNew bool, err error) {
+	key := newsStoreKey(symbol, id)
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(newsStoreBucket))
+		if b.Get(key) != nil {
+			isNew = false
+			return nil
+		}
+		isNew = true
+		val := make([]byte, 8)
+		binary.BigEndian.PutUint64(val, uint64(seenAt.Unix()))
+		return b.Put(key, val)
+	})
+	return isNew, err
+}
+
+// Prune deletes entries older than maxAge, to bound disk usage.
+func (s *NewsStore) Prune(maxAge time.Duration) error {
+	if maxAge <= 0 {
+		return nil
+	}
+	cutoff := time.Now().Add(-maxAge).Unix()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(newsStoreBucket))
+		c := b.Cursor()
+		var stale [][]byte
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if len(v) != 8 {
+				continue
+			}
+			seenAt := int64(binary.BigEndian.Uint64(v))
+			if seenAt < cutoff {
+				stale = append(stale, append([]byte(nil), k...))
+			}
+		}
+		for _, k := range stale {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
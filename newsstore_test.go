@@ -0,0 +1,96 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestNewsStore(t *testing.T) *NewsStore {
+	t.Helper()
+	store, err := OpenNewsStore(filepath.Join(t.TempDir(), "news.db"))
+	if err != nil {
+		t.Fatalf("OpenNewsStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestNewsStoreMarkSeen(t *testing.T) {
+	store := openTestNewsStore(t)
+
+	isNew, err := store.MarkSeen("AAPL", "article-1", time.Now())
+	if err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	if !isNew {
+		t.Fatal("expected first MarkSeen call to report isNew=true")
+	}
+
+	isNew, err = store.MarkSeen("AAPL", "article-1", time.Now())
+	if err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	if isNew {
+		t.Fatal("expected repeated MarkSeen call to report isNew=false")
+	}
+
+	isNew, err = store.MarkSeen("MSFT", "article-1", time.Now())
+	if err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	if !isNew {
+		t.Fatal("expected MarkSeen for a different symbol to report isNew=true, got false: keys must be scoped per-symbol")
+	}
+}
+
+func TestNewsStorePrune(t *testing.T) {
+	store := openTestNewsStore(t)
+
+	if _, err := store.MarkSeen("AAPL", "old", time.Now().Add(-2*time.Hour)); err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	if _, err := store.MarkSeen("AAPL", "recent", time.Now()); err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+
+	if err := store.Prune(time.Hour); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+
+	isNew, err := store.MarkSeen("AAPL", "old", time.Now())
+	if err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	if !isNew {
+		t.Fatal("expected pruned article to be forgotten and reported as new again")
+	}
+
+	isNew, err = store.MarkSeen("AAPL", "recent", time.Now())
+	if err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	if isNew {
+		t.Fatal("expected recent article to survive pruning")
+	}
+}
+
+func TestNewsStorePruneDisabledWhenMaxAgeZero(t *testing.T) {
+	store := openTestNewsStore(t)
+
+	if _, err := store.MarkSeen("AAPL", "old", time.Now().Add(-24*time.Hour)); err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+
+	if err := store.Prune(0); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+
+	isNew, err := store.MarkSeen("AAPL", "old", time.Now())
+	if err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	if isNew {
+		t.Fatal("expected Prune(0) to be a no-op, but the entry was forgotten")
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultFXRefreshInterval is how often FXCache refreshes exchange rates.
+const defaultFXRefreshInterval = 1 * time.Hour
+
+// defaultReportingCurrency is used when Config.ReportingCurrency is unset.
+const defaultReportingCurrency = "USD"
+
+// FXCache periodically refreshes the exchange rates needed to collapse a
+// mixed-currency portfolio into a single reporting currency.
+type FXCache struct {
+	mu       sync.RWMutex
+	rates    map[string]float64 // keyed by "FROM/TO"
+	pairs    [][2]string
+	provider FXProvider
+}
+
+// NewFXCache returns a new FXCache that keeps pairs refreshed through
+// provider.
+func NewFXCache(provider FXProvider, pairs [][2]string) *FXCache {
+	return &FXCache{
+		rates:    make(map[string]float64),
+		pairs:    pairs,
+		provider: provider,
+	}
+}
+
+// Run refreshes all configured pairs every interval until ctx is done. It
+// performs one refresh immediately so the cache is warm before the first
+// tick.
+func (c *FXCache) Run(ctx context.Context, interval time.Duration) {
+	c.refresh(ctx)
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+}
+
+func (c *FXCache) refresh(ctx context.Context) {
+	for _, pair := range c.pairs {
+		from, to := pair[0], pair[1]
+		rate, err := c.provider.Rate(ctx, from, to)
+		if err != nil {
+			slog.Error("Failed to refresh FX rate", "from", from, "to", to, "provider", c.provider.Name(), "err", err)
+			continue
+		}
+		c.mu.Lock()
+		c.rates[from+"/"+to] = rate
+		c.mu.Unlock()
+	}
+}
+
+// Rate returns the most recently cached rate to convert an amount in from
+// into to. ok is false if from differs from to and no rate has been
+// fetched yet.
+func (c *FXCache) Rate(from, to string) (rate float64, ok bool) {
+	if from == to {
+		return 1, true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	rate, ok = c.rates[from+"/"+to]
+	return rate, ok
+}
+
+var (
+	portfolioPositionValueDesc = prometheus.NewDesc(
+		"portfolio_position_value",
+		"Portfolio - Current value of a position, in the reporting currency",
+		[]string{"symbol"},
+		nil,
+	)
+	portfolioPositionPnlDesc = prometheus.NewDesc(
+		"portfolio_position_pnl",
+		"Portfolio - Unrealized profit or loss of a position, in the reporting currency",
+		[]string{"symbol"},
+		nil,
+	)
+	portfolioPositionAllocationDesc = prometheus.NewDesc(
+		"portfolio_position_allocation_percent",
+		"Portfolio - Share of total portfolio value held in a position",
+		[]string{"symbol"},
+		nil,
+	)
+	portfolioTotalValueDesc = prometheus.NewDesc(
+		"portfolio_total_value",
+		"Portfolio - Total value across all positions, in the reporting currency",
+		nil,
+		nil,
+	)
+	portfolioTotalPnlDesc = prometheus.NewDesc(
+		"portfolio_total_pnl",
+		"Portfolio - Total unrealized profit or loss across all positions, in the reporting currency",
+		nil,
+		nil,
+	)
+)
+
+// NewPortfolioCollector returns a new PortfolioCollector.
+func NewPortfolioCollector(holdings map[string]Holding, reportingCurrency string, quoteCache *QuoteCache, fxCache *FXCache) *PortfolioCollector {
+	return &PortfolioCollector{
+		holdings:          holdings,
+		reportingCurrency: reportingCurrency,
+		quoteCache:        quoteCache,
+		fxCache:           fxCache,
+	}
+}
+
+// PortfolioCollector emits holdings-weighted aggregate metrics derived from
+// QuoteCache, converting each position into ReportingCurrency via FXCache.
+type PortfolioCollector struct {
+	holdings          map[string]Holding
+	reportingCurrency string
+	quoteCache        *QuoteCache
+	fxCache           *FXCache
+}
+
+// Describe implements prometheus.Collector.Describe for PortfolioCollector.
+func (pc *PortfolioCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(pc, ch)
+}
+
+type portfolioPosition struct {
+	symbol string
+	value  float64
+	pnl    float64
+}
+
+// computePortfolioPositions converts holdings into per-symbol value and P&L
+// in reportingCurrency, given a quote lookup and an FX rate lookup matching
+// QuoteCache.Get and FXCache.Rate. It is factored out of Collect so the
+// math can be covered without standing up a live QuoteCache/FXCache.
+func computePortfolioPositions(holdings map[string]Holding, reportingCurrency string, getQuote func(symbol string) *Quote, getRate func(from, to string) (rate float64, ok bool)) (positions []portfolioPosition, totalValue, totalPnl float64) {
+	for symbol, holding := range holdings {
+		quote := getQuote(symbol)
+		if quote == nil {
+			continue
+		}
+		currency := holding.Currency
+		if currency == "" {
+			currency = reportingCurrency
+		}
+		rate, ok := getRate(currency, reportingCurrency)
+		if !ok {
+			slog.Warn("No FX rate cached, skipping position", "from", currency, "to", reportingCurrency, "symbol", symbol)
+			continue
+		}
+		value := quote.Current * holding.Shares * rate
+		pnl := (quote.Current - holding.AvgCost) * holding.Shares * rate
+		positions = append(positions, portfolioPosition{symbol: symbol, value: value, pnl: pnl})
+		totalValue += value
+		totalPnl += pnl
+	}
+	return positions, totalValue, totalPnl
+}
+
+// Collect implements prometheus.Collector.Collect for PortfolioCollector.
+func (pc *PortfolioCollector) Collect(ch chan<- prometheus.Metric) {
+	positions, totalValue, totalPnl := computePortfolioPositions(pc.holdings, pc.reportingCurrency,
+		func(symbol string) *Quote {
+			quote, _, _ := pc.quoteCache.Get(symbol)
+			return quote
+		},
+		pc.fxCache.Rate)
+
+	for _, p := range positions {
+		ch <- prometheus.MustNewConstMetric(portfolioPositionValueDesc, prometheus.GaugeValue, p.value, p.symbol)
+		ch <- prometheus.MustNewConstMetric(portfolioPositionPnlDesc, prometheus.GaugeValue, p.pnl, p.symbol)
+		var allocation float64
+		if totalValue != 0 {
+			allocation = p.value / totalValue * 100
+		}
+		ch <- prometheus.MustNewConstMetric(portfolioPositionAllocationDesc, prometheus.GaugeValue, allocation, p.symbol)
+	}
+	if len(positions) > 0 {
+		ch <- prometheus.MustNewConstMetric(portfolioTotalValueDesc, prometheus.GaugeValue, totalValue)
+		ch <- prometheus.MustNewConstMetric(portfolioTotalPnlDesc, prometheus.GaugeValue, totalPnl)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+const floatTolerance = 1e-9
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < floatTolerance
+}
+
+func TestComputePortfolioPositions(t *testing.T) {
+	holdings := map[string]Holding{
+		"AAPL": {Shares: 10, AvgCost: 100, Currency: "USD"},
+		"SAP":  {Shares: 5, AvgCost: 120, Currency: "EUR"},
+	}
+	quotes := map[string]*Quote{
+		"AAPL": {Current: 150},
+		"SAP":  {Current: 130},
+	}
+	rates := map[[2]string]float64{
+		{"USD", "USD"}: 1,
+		{"EUR", "USD"}: 1.1,
+	}
+	getQuote := func(symbol string) *Quote { return quotes[symbol] }
+	getRate := func(from, to string) (float64, bool) {
+		r, ok := rates[[2]string{from, to}]
+		return r, ok
+	}
+
+	positions, totalValue, totalPnl := computePortfolioPositions(holdings, "USD", getQuote, getRate)
+
+	if len(positions) != 2 {
+		t.Fatalf("expected 2 positions, got %d", len(positions))
+	}
+
+	var aapl, sap portfolioPosition
+	for _, p := range positions {
+		switch p.symbol {
+		case "AAPL":
+			aapl = p
+		case "SAP":
+			sap = p
+		}
+	}
+
+	wantAAPLValue := 150.0 * 10 * 1
+	wantAAPLPnl := (150.0 - 100) * 10 * 1
+	if !almostEqual(aapl.value, wantAAPLValue) {
+		t.Errorf("AAPL value = %v, want %v", aapl.value, wantAAPLValue)
+	}
+	if !almostEqual(aapl.pnl, wantAAPLPnl) {
+		t.Errorf("AAPL pnl = %v, want %v", aapl.pnl, wantAAPLPnl)
+	}
+
+	wantSAPValue := 130.0 * 5 * 1.1
+	wantSAPPnl := (130.0 - 120) * 5 * 1.1
+	if !almostEqual(sap.value, wantSAPValue) {
+		t.Errorf("SAP value = %v, want %v", sap.value, wantSAPValue)
+	}
+	if !almostEqual(sap.pnl, wantSAPPnl) {
+		t.Errorf("SAP pnl = %v, want %v", sap.pnl, wantSAPPnl)
+	}
+
+	wantTotalValue := wantAAPLValue + wantSAPValue
+	wantTotalPnl := wantAAPLPnl + wantSAPPnl
+	if !almostEqual(totalValue, wantTotalValue) {
+		t.Errorf("totalValue = %v, want %v", totalValue, wantTotalValue)
+	}
+	if !almostEqual(totalPnl, wantTotalPnl) {
+		t.Errorf("totalPnl = %v, want %v", totalPnl, wantTotalPnl)
+	}
+}
+
+func TestComputePortfolioPositionsSkipsMissingQuote(t *testing.T) {
+	holdings := map[string]Holding{
+		"AAPL": {Shares: 10, AvgCost: 100},
+	}
+	positions, totalValue, totalPnl := computePortfolioPositions(holdings, "USD",
+		func(symbol string) *Quote { return nil },
+		func(from, to string) (float64, bool) { return 1, true })
+
+	if len(positions) != 0 || totalValue != 0 || totalPnl != 0 {
+		t.Fatalf("expected no positions when quote is missing, got %+v value=%v pnl=%v", positions, totalValue, totalPnl)
+	}
+}
+
+func TestComputePortfolioPositionsSkipsMissingFXRate(t *testing.T) {
+	holdings := map[string]Holding{
+		"SAP": {Shares: 5, AvgCost: 120, Currency: "EUR"},
+	}
+	positions, totalValue, totalPnl := computePortfolioPositions(holdings, "USD",
+		func(symbol string) *Quote { return &Quote{Current: 130} },
+		func(from, to string) (float64, bool) { return 0, false })
+
+	if len(positions) != 0 || totalValue != 0 || totalPnl != 0 {
+		t.Fatalf("expected no positions when FX rate is unavailable, got %+v value=%v pnl=%v", positions, totalValue, totalPnl)
+	}
+}
+
+func TestComputePortfolioPositionsDefaultsCurrencyToReporting(t *testing.T) {
+	holdings := map[string]Holding{
+		"AAPL": {Shares: 2, AvgCost: 50},
+	}
+	var requestedFrom string
+	positions, _, _ := computePortfolioPositions(holdings, "USD",
+		func(symbol string) *Quote { return &Quote{Current: 60} },
+		func(from, to string) (float64, bool) {
+			requestedFrom = from
+			return 1, true
+		})
+
+	if requestedFrom != "USD" {
+		t.Errorf("expected FX lookup to default an empty Holding.Currency to the reporting currency, got from=%q", requestedFrom)
+	}
+	if len(positions) != 1 {
+		t.Fatalf("expected 1 position, got %d", len(positions))
+	}
+}
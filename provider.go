@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Quote is a normalized, provider-agnostic snapshot of a symbol's price.
+type Quote struct {
+	Symbol        string
+	Current       float64
+	Open          float64
+	High          float64
+	Low           float64
+	PreviousClose float64
+	Change        float64
+	PercentChange float64
+}
+
+// NewsItem is a single company news article.
+type NewsItem struct {
+	ID        string
+	Headline  string
+	URL       string
+	Timestamp time.Time
+}
+
+// Fundamentals holds slow-moving per-company financial data.
+type Fundamentals struct {
+	MarketCap     float64
+	PE            float64
+	WeekHigh52    float64
+	WeekLow52     float64
+	DividendYield float64
+	ShareFloat    float64
+}
+
+// ErrUnsupported is returned, wrapped, by a QuoteProvider method that the
+// backend does not implement at all, e.g. News on a quote-only provider.
+// Callers should check for it with errors.Is to tell a structurally
+// unsupported capability apart from a transient upstream failure.
+var ErrUnsupported = errors.New("not supported by this provider")
+
+// QuoteProvider is implemented by every backend capable of serving quotes,
+// company news and fundamentals for a symbol. Not every provider supports
+// every method: a provider that cannot serve News or Fundamentals at all
+// should return an error wrapping ErrUnsupported rather than zero values,
+// so that callers can tell "unsupported" apart from "no data today".
+type QuoteProvider interface {
+	// Name returns the provider's short identifier, e.g. "finnhub".
+	Name() string
+	// Quote returns the latest quote for symbol.
+	Quote(ctx context.Context, symbol string) (*Quote, error)
+	// News returns company news for symbol between from and to.
+	News(ctx context.Context, symbol string, from, to time.Time) ([]NewsItem, error)
+	// Fundamentals returns slow-moving per-company financial data for symbol.
+	Fundamentals(ctx context.Context, symbol string) (*Fundamentals, error)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const alphaVantageDefaultBaseURL = "https://www.alphavantage.co"
+
+// AlphaVantageProvider implements QuoteProvider on top of the Alpha Vantage
+// REST API. It supports quotes and fundamentals, but not company news.
+type AlphaVantageProvider struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// NewAlphaVantageProvider returns a new AlphaVantageProvider. If baseURL is
+// empty, the default public Alpha Vantage endpoint is used.
+func NewAlphaVantageProvider(apiKey, baseURL string) *AlphaVantageProvider {
+	if baseURL == "" {
+		baseURL = alphaVantageDefaultBaseURL
+	}
+	return &AlphaVantageProvider{apiKey: apiKey, baseURL: baseURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Name implements QuoteProvider.
+func (p *AlphaVantageProvider) Name() string { return "alphavantage" }
+
+type alphaVantageQuoteResponse struct {
+	GlobalQuote struct {
+		Price            string `json:"05. price"`
+		Open             string `json:"02. open"`
+		High             string `json:"03. high"`
+		Low              string `json:"04. low"`
+		PreviousClose    string `json:"08. previous close"`
+		Change           string `json:"09. change"`
+		ChangePercentStr string `json:"10. change percent"`
+	} `json:"Global Quote"`
+}
+
+func (p *AlphaVantageProvider) get(ctx context.Context, query url.Values, out interface{}) error {
+	query.Set("apikey", p.apiKey)
+	u := fmt.Sprintf("%s/query?%s", p.baseURL, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("alphavantage: failed to build request: %w", err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("alphavantage: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("alphavantage: unexpected status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("alphavantage: failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func parseFloatOrZero(s string) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// parsePercentOrZero parses a percentage string such as "0.52%", stripping
+// the trailing '%' Alpha Vantage includes before delegating to
+// parseFloatOrZero.
+func parsePercentOrZero(s string) float64 {
+	return parseFloatOrZero(strings.TrimSuffix(s, "%"))
+}
+
+// Quote implements QuoteProvider.
+func (p *AlphaVantageProvider) Quote(ctx context.Context, symbol string) (*Quote, error) {
+	var res alphaVantageQuoteResponse
+	query := url.Values{"function": {"GLOBAL_QUOTE"}, "symbol": {symbol}}
+	if err := p.get(ctx, query, &res); err != nil {
+		return nil, fmt.Errorf("alphavantage: failed to get quote for '%s': %w", symbol, err)
+	}
+	if res.GlobalQuote.Price == "" {
+		return nil, fmt.Errorf("alphavantage: no quote data returned for '%s'", symbol)
+	}
+	return &Quote{
+		Symbol:        symbol,
+		Current:       parseFloatOrZero(res.GlobalQuote.Price),
+		Open:          parseFloatOrZero(res.GlobalQuote.Open),
+		High:          parseFloatOrZero(res.GlobalQuote.High),
+		Low:           parseFloatOrZero(res.GlobalQuote.Low),
+		PreviousClose: parseFloatOrZero(res.GlobalQuote.PreviousClose),
+		Change:        parseFloatOrZero(res.GlobalQuote.Change),
+		PercentChange: parsePercentOrZero(res.GlobalQuote.ChangePercentStr),
+	}, nil
+}
+
+// News implements QuoteProvider. Alpha Vantage's news sentiment endpoint
+// requires a separate, less widely available entitlement, so it is left
+// unsupported here.
+func (p *AlphaVantageProvider) News(ctx context.Context, symbol string, from, to time.Time) ([]NewsItem, error) {
+	return nil, fmt.Errorf("alphavantage: News: %w", ErrUnsupported)
+}
+
+type alphaVantageOverviewResponse struct {
+	MarketCapitalization string `json:"MarketCapitalization"`
+	PERatio              string `json:"PERatio"`
+	WeekHigh52           string `json:"52WeekHigh"`
+	WeekLow52            string `json:"52WeekLow"`
+	DividendYield        string `json:"DividendYield"`
+	SharesFloat          string `json:"SharesFloat"`
+}
+
+// Fundamentals implements QuoteProvider.
+func (p *AlphaVantageProvider) Fundamentals(ctx context.Context, symbol string) (*Fundamentals, error) {
+	var res alphaVantageOverviewResponse
+	query := url.Values{"function": {"OVERVIEW"}, "symbol": {symbol}}
+	if err := p.get(ctx, query, &res); err != nil {
+		return nil, fmt.Errorf("alphavantage: failed to get overview for '%s': %w", symbol, err)
+	}
+	if res.MarketCapitalization == "" {
+		return nil, fmt.Errorf("alphavantage: no overview data returned for '%s'", symbol)
+	}
+	return &Fundamentals{
+		MarketCap:     parseFloatOrZero(res.MarketCapitalization),
+		PE:            parseFloatOrZero(res.PERatio),
+		WeekHigh52:    parseFloatOrZero(res.WeekHigh52),
+		WeekLow52:     parseFloatOrZero(res.WeekLow52),
+		DividendYield: parseFloatOrZero(res.DividendYield),
+		ShareFloat:    parseFloatOrZero(res.SharesFloat),
+	}, nil
+}
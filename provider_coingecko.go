@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const coinGeckoDefaultBaseURL = "https://api.coingecko.com/api/v3"
+
+// CoinGeckoProvider implements QuoteProvider on top of the CoinGecko public
+// API, for crypto symbols such as "bitcoin-usd". It supports quotes only:
+// neither company news nor fundamentals, including market cap.
+//
+// Symbols are expected in "ID-CURRENCY" form, e.g. "bitcoin-usd"; CoinGecko
+// identifies coins by slug rather than ticker, and tickers such as "BTC-USD"
+// are not translated, so a coin must be listed in symbols by its slug.
+type CoinGeckoProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewCoinGeckoProvider returns a new CoinGeckoProvider. If baseURL is empty,
+// the default public CoinGecko endpoint is used.
+func NewCoinGeckoProvider(baseURL string) *CoinGeckoProvider {
+	if baseURL == "" {
+		baseURL = coinGeckoDefaultBaseURL
+	}
+	return &CoinGeckoProvider{baseURL: baseURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Name implements QuoteProvider.
+func (p *CoinGeckoProvider) Name() string { return "coingecko" }
+
+func splitCoinSymbol(symbol string) (id, currency string, err error) {
+	parts := strings.SplitN(symbol, "-", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("coingecko: symbol '%s' must be in 'id-currency' form, e.g. 'bitcoin-usd'", symbol)
+	}
+	return strings.ToLower(parts[0]), strings.ToLower(parts[1]), nil
+}
+
+// Quote implements QuoteProvider.
+func (p *CoinGeckoProvider) Quote(ctx context.Context, symbol string) (*Quote, error) {
+	id, currency, err := splitCoinSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{
+		"ids":                 {id},
+		"vs_currencies":       {currency},
+		"include_24hr_change": {"true"},
+		"include_market_cap":  {"true"},
+	}
+	u := fmt.Sprintf("%s/simple/price?%s", p.baseURL, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("coingecko: failed to build request for '%s': %w", symbol, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("coingecko: request failed for '%s': %w", symbol, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("coingecko: unexpected status %d for '%s'", resp.StatusCode, symbol)
+	}
+	var res map[string]map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("coingecko: failed to decode response for '%s': %w", symbol, err)
+	}
+	data, ok := res[id]
+	if !ok {
+		return nil, fmt.Errorf("coingecko: no data returned for '%s'", symbol)
+	}
+	price, ok := data[currency]
+	if !ok {
+		return nil, fmt.Errorf("coingecko: no price in '%s' for '%s'", currency, symbol)
+	}
+	return &Quote{
+		Symbol:        symbol,
+		Current:       price,
+		PercentChange: data[currency+"_24h_change"],
+	}, nil
+}
+
+// News implements QuoteProvider. CoinGecko's free tier has no company news
+// equivalent, so this is unsupported.
+func (p *CoinGeckoProvider) News(ctx context.Context, symbol string, from, to time.Time) ([]NewsItem, error) {
+	return nil, fmt.Errorf("coingecko: News: %w", ErrUnsupported)
+}
+
+// Fundamentals implements QuoteProvider. A dedicated fundamentals concept
+// does not apply to crypto assets, so this is unsupported. The market cap
+// that Quote's include_market_cap option requests is not surfaced either.
+func (p *CoinGeckoProvider) Fundamentals(ctx context.Context, symbol string) (*Fundamentals, error) {
+	return nil, fmt.Errorf("coingecko: Fundamentals: %w", ErrUnsupported)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	finnhub "github.com/Finnhub-Stock-API/finnhub-go/v2"
+)
+
+// FinnhubProvider implements QuoteProvider on top of the Finnhub API. It
+// supports quotes, company news and basic fundamentals.
+type FinnhubProvider struct {
+	client *finnhub.DefaultApiService
+}
+
+// NewFinnhubProvider returns a new FinnhubProvider authenticated with apiKey.
+func NewFinnhubProvider(apiKey string) *FinnhubProvider {
+	cfg := finnhub.NewConfiguration()
+	cfg.AddDefaultHeader("X-Finnhub-Token", apiKey)
+	return &FinnhubProvider{client: finnhub.NewAPIClient(cfg).DefaultApi}
+}
+
+// Name implements QuoteProvider.
+func (p *FinnhubProvider) Name() string { return "finnhub" }
+
+// Quote implements QuoteProvider.
+func (p *FinnhubProvider) Quote(ctx context.Context, symbol string) (*Quote, error) {
+	res, _, err := p.client.Quote(ctx).Symbol(symbol).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("finnhub: failed to get quote for '%s': %w", symbol, err)
+	}
+	if res.C == nil {
+		return nil, fmt.Errorf("finnhub: quote for '%s' has no current price", symbol)
+	}
+	q := &Quote{Symbol: symbol, Current: float64(*res.C)}
+	if res.O != nil {
+		q.Open = float64(*res.O)
+	}
+	if res.H != nil {
+		q.High = float64(*res.H)
+	}
+	if res.L != nil {
+		q.Low = float64(*res.L)
+	}
+	if res.Pc != nil {
+		q.PreviousClose = float64(*res.Pc)
+	}
+	if res.D != nil {
+		q.Change = float64(*res.D)
+	}
+	if res.Dp != nil {
+		q.PercentChange = float64(*res.Dp)
+	}
+	return q, nil
+}
+
+// News implements QuoteProvider.
+func (p *FinnhubProvider) News(ctx context.Context, symbol string, from, to time.Time) ([]NewsItem, error) {
+	res, _, err := p.client.CompanyNews(ctx).Symbol(symbol).From(from.Format("2006-01-02")).To(to.Format("2006-01-02")).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("finnhub: failed to get company news for '%s': %w", symbol, err)
+	}
+	var items []NewsItem
+	for _, news := range res {
+		if news.Datetime == nil || news.Headline == nil || news.Id == nil || news.Url == nil {
+			continue
+		}
+		items = append(items, NewsItem{
+			ID:        fmt.Sprintf("%d", *news.Id),
+			Headline:  *news.Headline,
+			URL:       *news.Url,
+			Timestamp: time.Unix(*news.Datetime, 0),
+		})
+	}
+	return items, nil
+}
+
+// Fundamentals implements QuoteProvider.
+func (p *FinnhubProvider) Fundamentals(ctx context.Context, symbol string) (*Fundamentals, error) {
+	res, _, err := p.client.CompanyBasicFinancials(ctx).Symbol(symbol).Metric("all").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("finnhub: failed to get basic financials for '%s': %w", symbol, err)
+	}
+	if res.Metric == nil {
+		return nil, fmt.Errorf("finnhub: no metrics returned for '%s'", symbol)
+	}
+	metric := *res.Metric
+	f := &Fundamentals{}
+	if v, ok := metric["marketCapitalization"].(float64); ok {
+		f.MarketCap = v
+	}
+	if v, ok := metric["peBasicExclExtraTTM"].(float64); ok {
+		f.PE = v
+	}
+	if v, ok := metric["52WeekHigh"].(float64); ok {
+		f.WeekHigh52 = v
+	}
+	if v, ok := metric["52WeekLow"].(float64); ok {
+		f.WeekLow52 = v
+	}
+	if v, ok := metric["dividendYieldIndicatedAnnual"].(float64); ok {
+		f.DividendYield = v
+	}
+	if v, ok := metric["shareOutstanding"].(float64); ok {
+		f.ShareFloat = v
+	}
+	return f, nil
+}
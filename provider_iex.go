@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const iexDefaultBaseURL = "https://cloud.iexapis.com/stable"
+
+// IEXProvider implements QuoteProvider on top of the IEX Cloud REST API. It
+// supports quotes and fundamentals, but not company news.
+type IEXProvider struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// NewIEXProvider returns a new IEXProvider. If baseURL is empty, the default
+// public IEX Cloud endpoint is used.
+func NewIEXProvider(apiKey, baseURL string) *IEXProvider {
+	if baseURL == "" {
+		baseURL = iexDefaultBaseURL
+	}
+	return &IEXProvider{apiKey: apiKey, baseURL: baseURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Name implements QuoteProvider.
+func (p *IEXProvider) Name() string { return "iex" }
+
+type iexQuoteResponse struct {
+	LatestPrice   float64 `json:"latestPrice"`
+	Open          float64 `json:"iexOpen"`
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	PreviousClose float64 `json:"previousClose"`
+	Change        float64 `json:"change"`
+	ChangePercent float64 `json:"changePercent"`
+	MarketCap     float64 `json:"marketCap"`
+	PeRatio       float64 `json:"peRatio"`
+	Week52High    float64 `json:"week52High"`
+	Week52Low     float64 `json:"week52Low"`
+}
+
+func (p *IEXProvider) getQuote(ctx context.Context, symbol string) (*iexQuoteResponse, error) {
+	u := fmt.Sprintf("%s/stock/%s/quote?%s", p.baseURL, url.PathEscape(symbol), url.Values{"token": {p.apiKey}}.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("iex: failed to build request for '%s': %w", symbol, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("iex: request failed for '%s': %w", symbol, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("iex: unexpected status %d for '%s'", resp.StatusCode, symbol)
+	}
+	var res iexQuoteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("iex: failed to decode response for '%s': %w", symbol, err)
+	}
+	return &res, nil
+}
+
+// Quote implements QuoteProvider.
+func (p *IEXProvider) Quote(ctx context.Context, symbol string) (*Quote, error) {
+	res, err := p.getQuote(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return &Quote{
+		Symbol:        symbol,
+		Current:       res.LatestPrice,
+		Open:          res.Open,
+		High:          res.High,
+		Low:           res.Low,
+		PreviousClose: res.PreviousClose,
+		Change:        res.Change,
+		PercentChange: res.ChangePercent * 100,
+	}, nil
+}
+
+// News implements QuoteProvider. IEX Cloud's news endpoint is a separate,
+// metered product, so it is left unsupported here.
+func (p *IEXProvider) News(ctx context.Context, symbol string, from, to time.Time) ([]NewsItem, error) {
+	return nil, fmt.Errorf("iex: News: %w", ErrUnsupported)
+}
+
+// Fundamentals implements QuoteProvider. The plain quote endpoint already
+// carries market cap, P/E and 52-week range, so no extra request is needed.
+func (p *IEXProvider) Fundamentals(ctx context.Context, symbol string) (*Fundamentals, error) {
+	res, err := p.getQuote(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return &Fundamentals{
+		MarketCap:  res.MarketCap,
+		PE:         res.PeRatio,
+		WeekHigh52: res.Week52High,
+		WeekLow52:  res.Week52Low,
+	}, nil
+}
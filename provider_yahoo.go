@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const yahooDefaultBaseURL = "https://query1.finance.yahoo.com"
+
+// YahooProvider implements QuoteProvider using Yahoo Finance's public,
+// unauthenticated chart endpoint. It requires no API key, which makes it a
+// reasonable default for users without a Finnhub subscription. It does not
+// support News or Fundamentals.
+type YahooProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewYahooProvider returns a new YahooProvider. If baseURL is empty, the
+// default public Yahoo Finance endpoint is used.
+func NewYahooProvider(baseURL string) *YahooProvider {
+	if baseURL == "" {
+		baseURL = yahooDefaultBaseURL
+	}
+	return &YahooProvider{baseURL: baseURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Name implements QuoteProvider.
+func (p *YahooProvider) Name() string { return "yahoo" }
+
+type yahooChartResponse struct {
+	Chart struct {
+		Result []struct {
+			Meta struct {
+				RegularMarketPrice float64 `json:"regularMarketPrice"`
+				PreviousClose      float64 `json:"chartPreviousClose"`
+			} `json:"meta"`
+		} `json:"result"`
+	} `json:"chart"`
+}
+
+// Quote implements QuoteProvider.
+func (p *YahooProvider) Quote(ctx context.Context, symbol string) (*Quote, error) {
+	u := fmt.Sprintf("%s/v8/finance/chart/%s?range=1d&interval=1m", p.baseURL, url.PathEscape(symbol))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("yahoo: failed to build request for '%s': %w", symbol, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("yahoo: request failed for '%s': %w", symbol, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("yahoo: unexpected status %d for '%s'", resp.StatusCode, symbol)
+	}
+	var cr yahooChartResponse
+	if err := json.NewDecoder(resp.Body).Decode(&cr); err != nil {
+		return nil, fmt.Errorf("yahoo: failed to decode response for '%s': %w", symbol, err)
+	}
+	if len(cr.Chart.Result) == 0 {
+		return nil, fmt.Errorf("yahoo: no data returned for '%s'", symbol)
+	}
+	meta := cr.Chart.Result[0].Meta
+	q := &Quote{
+		Symbol:        symbol,
+		Current:       meta.RegularMarketPrice,
+		PreviousClose: meta.PreviousClose,
+	}
+	if meta.PreviousClose != 0 {
+		q.Change = q.Current - q.PreviousClose
+		q.PercentChange = q.Change / q.PreviousClose * 100
+	}
+	return q, nil
+}
+
+// News implements QuoteProvider. Yahoo's public chart endpoint does not
+// expose company news, so this is unsupported.
+func (p *YahooProvider) News(ctx context.Context, symbol string, from, to time.Time) ([]NewsItem, error) {
+	return nil, fmt.Errorf("yahoo: News: %w", ErrUnsupported)
+}
+
+// Fundamentals implements QuoteProvider. Unsupported for the same reason as News.
+func (p *YahooProvider) Fundamentals(ctx context.Context, symbol string) (*Fundamentals, error) {
+	return nil, fmt.Errorf("yahoo: Fundamentals: %w", ErrUnsupported)
+}